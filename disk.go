@@ -0,0 +1,252 @@
+package wordcount
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// approxRecordOverhead estimates the per-entry bookkeeping cost of a
+// map[string]int, on top of the word's own bytes, for deciding when
+// Options.MaxMemBytes has been crossed.
+const approxRecordOverhead = 48
+
+// DiskBackedCounter accumulates word counts like WordCount, but spills
+// its in-memory map to a sorted run file once it crosses
+// Options.MaxMemBytes, then reconstructs the full counts with an
+// external k-way merge over the runs. This keeps memory use bounded by
+// MaxMemBytes rather than by the size of the corpus, at the cost of
+// some disk I/O.
+type DiskBackedCounter struct {
+	dir          string
+	opts         Options
+	current      WordCount
+	currentBytes int
+	runs         []string
+	seq          int
+}
+
+// NewDiskBackedCounter returns a DiskBackedCounter that spills its runs
+// as files under dir, which must already exist.
+func NewDiskBackedCounter(dir string, opts Options) *DiskBackedCounter {
+	return &DiskBackedCounter{dir: dir, opts: opts, current: make(WordCount)}
+}
+
+// CountReader tokenizes r and adds its words to the counter, spilling
+// to disk whenever Options.MaxMemBytes is crossed. It has the same
+// signature as WordCount.CountReader so callers can swap one
+// implementation for the other.
+func (d *DiskBackedCounter) CountReader(r io.Reader) error {
+	t := d.opts.newTokenizer(r)
+	for {
+		word, ok := t.Next()
+		if !ok {
+			break
+		}
+		word = d.opts.fold(word)
+		if !d.opts.keep(word) {
+			continue
+		}
+		if _, exists := d.current[word]; !exists {
+			d.currentBytes += len(word) + approxRecordOverhead
+		}
+		d.current[word]++
+		if d.opts.MaxMemBytes > 0 && d.currentBytes >= d.opts.MaxMemBytes {
+			if err := d.spill(); err != nil {
+				return err
+			}
+		}
+	}
+	if e, ok := t.(interface{ Err() error }); ok {
+		return e.Err()
+	}
+	return nil
+}
+
+// spill writes the in-memory map to a new word-sorted run file and
+// resets it.
+func (d *DiskBackedCounter) spill() error {
+	if len(d.current) == 0 {
+		return nil
+	}
+	words := make([]string, 0, len(d.current))
+	for word := range d.current {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+
+	d.seq++
+	path := filepath.Join(d.dir, fmt.Sprintf("run-%04d.gob", d.seq))
+	if err := writeRun(path, words, d.current); err != nil {
+		return err
+	}
+
+	d.runs = append(d.runs, path)
+	d.current = make(WordCount)
+	d.currentBytes = 0
+	return nil
+}
+
+func writeRun(path string, words []string, counts WordCount) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	bw := bufio.NewWriter(f)
+	enc := gob.NewEncoder(bw)
+	for _, word := range words {
+		if err = enc.Encode(wordCountRecord{word, counts[word]}); err != nil {
+			return err
+		}
+	}
+	err = bw.Flush()
+	return err
+}
+
+// runIterator reads one gob-encoded run file in word-sorted order,
+// always holding the next unread record.
+type runIterator struct {
+	file *os.File
+	dec  *gob.Decoder
+	cur  wordCountRecord
+	ok   bool
+}
+
+func newRunIterator(path string) (*runIterator, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	it := &runIterator{file: f, dec: gob.NewDecoder(bufio.NewReader(f))}
+	it.advance()
+	return it, nil
+}
+
+func (it *runIterator) advance() {
+	it.ok = it.dec.Decode(&it.cur) == nil
+}
+
+func (it *runIterator) Close() error {
+	return it.file.Close()
+}
+
+// runHeap orders runIterators by their current word, so heap.Pop
+// always returns the iterator holding the next word in merge order.
+type runHeap []*runIterator
+
+func (h runHeap) Len() int            { return len(h) }
+func (h runHeap) Less(i, j int) bool  { return h[i].cur.Word < h[j].cur.Word }
+func (h runHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *runHeap) Push(x interface{}) { *h = append(*h, x.(*runIterator)) }
+func (h *runHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+// mergeRuns spills any remaining in-memory data, then performs an
+// external k-way merge over every run file, calling emit once per word
+// in ascending order with its total count summed across runs. Words
+// whose summed count falls below Options.MinCount are dropped, so
+// DiskBackedCounter honors MinCount the same way WordFreqCounterOptions
+// and CountSources do.
+func (d *DiskBackedCounter) mergeRuns(emit func(word string, count int) error) error {
+	if err := d.spill(); err != nil {
+		return err
+	}
+	if len(d.runs) == 0 {
+		return nil
+	}
+
+	iterators := make([]*runIterator, 0, len(d.runs))
+	h := make(runHeap, 0, len(d.runs))
+	defer func() {
+		for _, it := range iterators {
+			it.Close()
+		}
+	}()
+	for _, path := range d.runs {
+		it, err := newRunIterator(path)
+		if err != nil {
+			return err
+		}
+		iterators = append(iterators, it)
+		if it.ok {
+			h = append(h, it)
+		}
+	}
+	heap.Init(&h)
+
+	for h.Len() > 0 {
+		word := h[0].cur.Word
+		total := 0
+		for h.Len() > 0 && h[0].cur.Word == word {
+			it := heap.Pop(&h).(*runIterator)
+			total += it.cur.Count
+			it.advance()
+			if it.ok {
+				heap.Push(&h, it)
+			}
+		}
+		if d.opts.MinCount > 0 && total < d.opts.MinCount {
+			continue
+		}
+		if err := emit(word, total); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SortReport merges every run and writes the result to out in the same
+// most-frequent-first format as WordCount.SortReport. Producing
+// frequency order needs every word's final count, so unlike WriteReport
+// this cannot stream: it collects the merged pairs before sorting them.
+func (d *DiskBackedCounter) SortReport(out io.Writer) error {
+	var pairs PairList
+	if err := d.mergeRuns(func(word string, count int) error {
+		pairs = append(pairs, Pair{word, count})
+		return nil
+	}); err != nil {
+		return err
+	}
+	sort.Sort(pairs)
+	return TextReporter{}.Write(out, pairs)
+}
+
+// WriteReport streams the merged, word-sorted counts to out via
+// reporter as the external merge progresses, without ever holding the
+// full vocabulary in memory at once.
+func (d *DiskBackedCounter) WriteReport(out io.Writer, reporter RowReporter) error {
+	if err := reporter.WriteHeader(out); err != nil {
+		return err
+	}
+	return d.mergeRuns(func(word string, count int) error {
+		return reporter.WriteRow(out, Pair{word, count})
+	})
+}
+
+// Close removes every run file the counter has spilled to disk. It
+// does not remove dir itself, since the caller owns that.
+func (d *DiskBackedCounter) Close() error {
+	for _, path := range d.runs {
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+	d.runs = nil
+	return nil
+}
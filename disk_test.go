@@ -0,0 +1,148 @@
+package wordcount
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// parseReport turns a TextReporter/SortReport rendering back into a
+// word->count map, so tests can compare reports without depending on
+// the unspecified tie-break order between equally frequent words.
+func parseReport(t *testing.T, report string) map[string]int {
+	t.Helper()
+	lines := strings.Split(strings.TrimSpace(report), "\n")
+	got := make(map[string]int, len(lines)-1)
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			t.Fatalf("malformed report line %q", line)
+		}
+		count, err := strconv.Atoi(fields[1])
+		if err != nil {
+			t.Fatalf("malformed count in line %q: %v", line, err)
+		}
+		got[fields[0]] = count
+	}
+	return got
+}
+
+func TestDiskBackedCounterForcesMultipleSpills(t *testing.T) {
+	dir := t.TempDir()
+	// Small enough that every word triggers a spill, so the merge path
+	// is genuinely exercised rather than trivially no-op.
+	d := NewDiskBackedCounter(dir, Options{MaxMemBytes: 1})
+
+	texts := []string{
+		"the quick brown fox jumps over the lazy dog",
+		"the dog barks at the fox",
+		"quick quick quick fox fox",
+	}
+	for _, text := range texts {
+		if err := d.CountReader(strings.NewReader(text)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) < 2 {
+		t.Fatalf("expected MaxMemBytes:1 to force multiple run files, got %d", len(entries))
+	}
+
+	var buf bytes.Buffer
+	if err := d.SortReport(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := make(WordCount)
+	for _, text := range texts {
+		if err := want.CountReaderOptions(strings.NewReader(text), Options{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var wantBuf bytes.Buffer
+	if err := (TextReporter{}).Write(&wantBuf, want.SortedPairs()); err != nil {
+		t.Fatal(err)
+	}
+
+	gotCounts := parseReport(t, buf.String())
+	wantCounts := parseReport(t, wantBuf.String())
+	if !reflect.DeepEqual(gotCounts, wantCounts) {
+		t.Fatalf("disk-backed counts %v don't match in-memory counts %v", gotCounts, wantCounts)
+	}
+
+	if err := d.Close(); err != nil {
+		t.Fatal(err)
+	}
+	entries, err = os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("Close left %d run files behind, want 0", len(entries))
+	}
+}
+
+func TestDiskBackedCounterMinCount(t *testing.T) {
+	dir := t.TempDir()
+	d := NewDiskBackedCounter(dir, Options{MaxMemBytes: 1, MinCount: 2})
+
+	if err := d.CountReader(strings.NewReader("apple apple banana cherry cherry cherry")); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := d.SortReport(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "Word       Frequency\n" +
+		"cherry 3\n" +
+		"apple  2\n"
+	if buf.String() != want {
+		t.Fatalf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestDiskBackedCounterWriteReportStreamsNDJSON(t *testing.T) {
+	dir := t.TempDir()
+	d := NewDiskBackedCounter(dir, Options{MaxMemBytes: 1})
+	if err := d.CountReader(strings.NewReader("alpha beta alpha gamma")); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := d.WriteReport(&buf, NDJSONReporter{}); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"word":"alpha","count":2}` + "\n" +
+		`{"word":"beta","count":1}` + "\n" +
+		`{"word":"gamma","count":1}` + "\n"
+	if buf.String() != want {
+		t.Fatalf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestDiskBackedCounterNoSpillsMatchesWordCount(t *testing.T) {
+	dir := t.TempDir()
+	d := NewDiskBackedCounter(dir, Options{})
+	if err := d.CountReader(strings.NewReader("a a b")); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := d.SortReport(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "Word            Frequency\n" +
+		"a 2\n" +
+		"b 1\n"
+	if buf.String() != want {
+		t.Fatalf("got %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,142 @@
+package wordcount
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// ExpandOptions controls ExpandArgs.
+type ExpandOptions struct {
+	// Recursive walks directory arguments via filepath.WalkDir instead
+	// of rejecting them.
+	Recursive bool
+	// Include, if non-empty, restricts recursive walks to files whose
+	// base name matches one of these filepath.Match patterns.
+	Include []string
+	// Exclude skips files whose base name matches one of these
+	// filepath.Match patterns, even if Include matched.
+	Exclude []string
+	// Stdin is read when an argument is exactly "-". Defaults to
+	// os.Stdin.
+	Stdin io.Reader
+}
+
+// ExpandArgs turns raw command-line arguments into a flat list of file
+// paths. It globs arguments on Windows, where cmd.exe does not expand
+// wildcards like *.txt itself, walks directory arguments when
+// Recursive is set, and reads a newline-separated file list from
+// "@listfile" or, when the argument is "-", from stdin.
+func ExpandArgs(args []string, opts ExpandOptions) ([]string, error) {
+	var files []string
+	for _, arg := range args {
+		switch {
+		case arg == "-":
+			list, err := readList(stdinOrDefault(opts))
+			if err != nil {
+				return nil, fmt.Errorf("read stdin: %w", err)
+			}
+			files = append(files, list...)
+		case strings.HasPrefix(arg, "@"):
+			list, err := readListFile(arg[1:])
+			if err != nil {
+				return nil, fmt.Errorf("read %s: %w", arg, err)
+			}
+			files = append(files, list...)
+		default:
+			matches, err := expandOne(arg, opts)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, matches...)
+		}
+	}
+	return files, nil
+}
+
+func stdinOrDefault(opts ExpandOptions) io.Reader {
+	if opts.Stdin != nil {
+		return opts.Stdin
+	}
+	return os.Stdin
+}
+
+func readList(r io.Reader) ([]string, error) {
+	var files []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, scanner.Err()
+}
+
+func readListFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return readList(f)
+}
+
+// expandOne resolves a single non-special argument: a directory (when
+// Recursive), a glob (on Windows, where the shell leaves it
+// unexpanded), or a plain file path.
+func expandOne(arg string, opts ExpandOptions) ([]string, error) {
+	if info, err := os.Stat(arg); err == nil && info.IsDir() {
+		if !opts.Recursive {
+			return nil, fmt.Errorf("%s is a directory (use -r to recurse)", arg)
+		}
+		return walkDir(arg, opts)
+	}
+
+	if runtime.GOOS == "windows" {
+		matches, err := filepath.Glob(arg)
+		if err != nil {
+			return nil, fmt.Errorf("glob %s: %w", arg, err)
+		}
+		if matches != nil {
+			return matches, nil
+		}
+	}
+	return []string{arg}, nil
+}
+
+func walkDir(root string, opts ExpandOptions) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if !matchesPatterns(d.Name(), opts.Include, true) || matchesPatterns(d.Name(), opts.Exclude, false) {
+			return nil
+		}
+		files = append(files, path)
+		return nil
+	})
+	return files, err
+}
+
+// matchesPatterns reports whether name matches any of patterns,
+// falling back to defaultIfEmpty when patterns is empty.
+func matchesPatterns(name string, patterns []string, defaultIfEmpty bool) bool {
+	if len(patterns) == 0 {
+		return defaultIfEmpty
+	}
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package wordcount
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func writeTempFiles(t *testing.T, dir string, names ...string) []string {
+	t.Helper()
+	paths := make([]string, len(names))
+	for i, name := range names {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		paths[i] = path
+	}
+	return paths
+}
+
+func TestExpandArgsPlainFiles(t *testing.T) {
+	dir := t.TempDir()
+	paths := writeTempFiles(t, dir, "a.txt", "b.txt")
+
+	got, err := ExpandArgs(paths, ExpandOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, paths) {
+		t.Fatalf("got %v, want %v", got, paths)
+	}
+}
+
+func TestExpandArgsDirectoryWithoutRecursiveErrors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := ExpandArgs([]string{dir}, ExpandOptions{}); err == nil {
+		t.Fatal("expected an error for a directory without -r")
+	}
+}
+
+func TestExpandArgsRecursiveWalksAndFilters(t *testing.T) {
+	dir := t.TempDir()
+	writeTempFiles(t, dir, "keep1.txt", "keep2.txt", "skip.log", "sub/keep3.txt")
+
+	got, err := ExpandArgs([]string{dir}, ExpandOptions{
+		Recursive: true,
+		Include:   []string{"*.txt"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(got)
+	want := []string{
+		filepath.Join(dir, "keep1.txt"),
+		filepath.Join(dir, "keep2.txt"),
+		filepath.Join(dir, "sub", "keep3.txt"),
+	}
+	sort.Strings(want)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestExpandArgsListFile(t *testing.T) {
+	dir := t.TempDir()
+	paths := writeTempFiles(t, dir, "one.txt", "two.txt")
+	listPath := filepath.Join(dir, "files.lst")
+	if err := os.WriteFile(listPath, []byte(strings.Join(paths, "\n")+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ExpandArgs([]string{"@" + listPath}, ExpandOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, paths) {
+		t.Fatalf("got %v, want %v", got, paths)
+	}
+}
+
+func TestExpandArgsStdin(t *testing.T) {
+	dir := t.TempDir()
+	paths := writeTempFiles(t, dir, "one.txt", "two.txt")
+
+	got, err := ExpandArgs([]string{"-"}, ExpandOptions{
+		Stdin: strings.NewReader(strings.Join(paths, "\n")),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, paths) {
+		t.Fatalf("got %v, want %v", got, paths)
+	}
+}
+
+func TestExpandArgsGlobOnWindows(t *testing.T) {
+	if runtime.GOOS != "windows" {
+		t.Skip("glob expansion only kicks in on windows, where cmd.exe leaves wildcards unexpanded")
+	}
+	dir := t.TempDir()
+	paths := writeTempFiles(t, dir, "a.txt", "b.txt")
+
+	got, err := ExpandArgs([]string{filepath.Join(dir, "*.txt")}, ExpandOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(got)
+	sort.Strings(paths)
+	if !reflect.DeepEqual(got, paths) {
+		t.Fatalf("got %v, want %v", got, paths)
+	}
+}
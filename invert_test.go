@@ -0,0 +1,42 @@
+package wordcount
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestInvertByFrequency(t *testing.T) {
+	w := WordCount{"the": 3, "a": 3, "fox": 2, "dog": 1}
+	got := w.InvertByFrequency()
+	want := map[int][]string{
+		3: {"a", "the"},
+		2: {"fox"},
+		1: {"dog"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestReportByFrequency(t *testing.T) {
+	w := WordCount{"the": 3, "a": 3, "fox": 2, "dog": 1}
+	var buf bytes.Buffer
+	w.ReportByFrequency(&buf)
+
+	want := "3 a\n" +
+		"  the\n" +
+		"2 fox\n" +
+		"1 dog\n"
+	if buf.String() != want {
+		t.Fatalf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestReportByFrequencyEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	WordCount{}.ReportByFrequency(&buf)
+	if buf.Len() != 0 {
+		t.Fatalf("expected no output for an empty WordCount, got %q", buf.String())
+	}
+}
@@ -0,0 +1,94 @@
+package wordcount
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Options controls the behaviour of CountSources and the counting
+// methods built on top of it. The zero value imposes no length or
+// stopword filtering, folds case, tokenizes with a LetterTokenizer,
+// and leaves WordFreqCounterOptions to size its worker pool by
+// runtime.GOMAXPROCS(0).
+type Options struct {
+	// Tokenizer builds the Tokenizer used for each Source. When nil,
+	// NewLetterTokenizer is used.
+	Tokenizer func(r io.Reader) Tokenizer
+
+	// Concurrency is the number of worker and shard-aggregator
+	// goroutines WordFreqCounterOptions uses. When <= 0, it defaults
+	// to runtime.GOMAXPROCS(0).
+	Concurrency int
+
+	// MinCount drops words that occur fewer than MinCount times from
+	// the final result. Zero disables this filter.
+	MinCount int
+
+	// MinLen and MaxLen bound accepted words by rune count. Zero
+	// disables the respective bound. Setting MinLen to 2 reproduces
+	// the package's old hard-coded rejection of single-rune words.
+	MinLen, MaxLen int
+
+	// Stopwords, when non-nil, names words (already folded per
+	// CaseSensitive) to discard entirely. See EnglishStopwords for a
+	// built-in set.
+	Stopwords map[string]struct{}
+
+	// CaseSensitive disables the package's historical behaviour of
+	// folding every word with strings.ToLower before counting it.
+	CaseSensitive bool
+
+	// MaxMemBytes bounds how much a DiskBackedCounter holds in memory
+	// before spilling the current run to disk. Zero disables spilling
+	// (equivalent to an ordinary WordCount).
+	MaxMemBytes int
+}
+
+func (o Options) newTokenizer(r io.Reader) Tokenizer {
+	if o.Tokenizer == nil {
+		return NewLetterTokenizer(r)
+	}
+	return o.Tokenizer(r)
+}
+
+// fold applies the package's case-folding default, unless the caller
+// opted out via CaseSensitive.
+func (o Options) fold(word string) string {
+	if o.CaseSensitive {
+		return word
+	}
+	return strings.ToLower(word)
+}
+
+// keep reports whether a folded word should be counted at all, per
+// MinLen, MaxLen and Stopwords.
+func (o Options) keep(word string) bool {
+	if o.MinLen > 0 || o.MaxLen > 0 {
+		n := utf8.RuneCountInString(word)
+		if o.MinLen > 0 && n < o.MinLen {
+			return false
+		}
+		if o.MaxLen > 0 && n > o.MaxLen {
+			return false
+		}
+	}
+	if o.Stopwords != nil {
+		if _, stop := o.Stopwords[word]; stop {
+			return false
+		}
+	}
+	return true
+}
+
+// applyMinCount drops every entry of w below MinCount, in place.
+func (o Options) applyMinCount(w WordCount) {
+	if o.MinCount <= 0 {
+		return
+	}
+	for word, count := range w {
+		if count < o.MinCount {
+			delete(w, word)
+		}
+	}
+}
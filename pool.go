@@ -0,0 +1,93 @@
+package wordcount
+
+import (
+	"hash/fnv"
+	"runtime"
+	"sync"
+)
+
+// wordCount is a (word, count) increment routed to its shard.
+type wordCount struct {
+	word  string
+	count int
+}
+
+// WordFreqCounterOptions counts files using a fixed worker pool, sized
+// by opts.Concurrency (or runtime.GOMAXPROCS(0) when unset), fed
+// through a single jobs channel. Each worker hashes every word it
+// finds with fnv32 and routes it to one of N shard channels, where N
+// also defaults to the worker count; each shard is owned by its own
+// aggregator goroutine, so no single receiver serializes the whole
+// merge. The shards are combined into w once every worker and
+// aggregator has finished, coordinated with sync.WaitGroup rather than
+// a draining loop.
+func (w WordCount) WordFreqCounterOptions(files []string, opts Options) {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = runtime.GOMAXPROCS(0)
+	}
+	if concurrency > len(files) && len(files) > 0 {
+		concurrency = len(files)
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	jobs := make(chan string)
+	shardChans := make([]chan wordCount, concurrency)
+	shards := make([]WordCount, concurrency)
+	for i := range shardChans {
+		shardChans[i] = make(chan wordCount, 64)
+		shards[i] = make(WordCount)
+	}
+
+	var aggregators sync.WaitGroup
+	aggregators.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func(shard WordCount, in <-chan wordCount) {
+			defer aggregators.Done()
+			for wc := range in {
+				shard[wc.word] += wc.count
+			}
+		}(shards[i], shardChans[i])
+	}
+
+	var workers sync.WaitGroup
+	workers.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer workers.Done()
+			for filename := range jobs {
+				fw := make(WordCount)
+				fw.UpdateFreqOptions(filename, opts)
+				for word, count := range fw {
+					shardChans[shardFor(word, concurrency)] <- wordCount{word, count}
+				}
+			}
+		}()
+	}
+
+	for _, file := range files {
+		jobs <- file
+	}
+	close(jobs)
+
+	workers.Wait()
+	for _, ch := range shardChans {
+		close(ch)
+	}
+	aggregators.Wait()
+
+	for _, shard := range shards {
+		w.Merge(shard)
+	}
+	opts.applyMinCount(w)
+}
+
+// shardFor picks the shard a word is aggregated on, so every
+// occurrence of the same word always lands in the same shard map.
+func shardFor(word string, shardCount int) int {
+	h := fnv.New32a()
+	h.Write([]byte(word))
+	return int(h.Sum32() % uint32(shardCount))
+}
@@ -0,0 +1,82 @@
+package wordcount
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func generateCorpus(tb testing.TB, dir string, files, wordsPerFile int) []string {
+	tb.Helper()
+	vocab := []string{"the", "quick", "brown", "fox", "jumps", "over", "lazy", "dog"}
+	paths := make([]string, files)
+	for i := 0; i < files; i++ {
+		var b strings.Builder
+		for j := 0; j < wordsPerFile; j++ {
+			b.WriteString(vocab[(i+j)%len(vocab)])
+			b.WriteByte(' ')
+		}
+		path := filepath.Join(dir, fmt.Sprintf("doc%d.txt", i))
+		if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+			tb.Fatal(err)
+		}
+		paths[i] = path
+	}
+	return paths
+}
+
+// legacyWordFreqCounter is the one-goroutine-per-file, single-channel
+// fan-in this package used before sharded aggregation. It is kept here
+// only so BenchmarkWordFreqCounterLegacy has something to compare
+// against.
+func legacyWordFreqCounter(w WordCount, files []string) {
+	results := make(chan Pair, len(files))
+	done := make(chan struct{}, len(files))
+
+	for _, filename := range files {
+		go func(filename string) {
+			fw := make(WordCount)
+			fw.UpdateFreq(filename)
+			for k, v := range fw {
+				results <- Pair{k, v}
+			}
+			done <- struct{}{}
+		}(filename)
+	}
+
+	for working := len(files); working > 0; {
+		select {
+		case pair := <-results:
+			w[pair.Key] += pair.Value
+		case <-done:
+			working--
+		}
+	}
+
+	for {
+		select {
+		case pair := <-results:
+			w[pair.Key] += pair.Value
+		default:
+			return
+		}
+	}
+}
+
+func BenchmarkWordFreqCounterLegacy(b *testing.B) {
+	files := generateCorpus(b, b.TempDir(), 500, 200)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		legacyWordFreqCounter(make(WordCount), files)
+	}
+}
+
+func BenchmarkWordFreqCounter(b *testing.B) {
+	files := generateCorpus(b, b.TempDir(), 500, 200)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		make(WordCount).WordFreqCounter(files)
+	}
+}
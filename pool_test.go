@@ -0,0 +1,65 @@
+package wordcount
+
+import (
+	"reflect"
+	"testing"
+)
+
+// sequentialCount builds the expected result for files by counting each
+// one with UpdateFreqOptions directly, with no concurrency involved.
+func sequentialCount(t *testing.T, files []string, opts Options) WordCount {
+	t.Helper()
+	w := make(WordCount)
+	for _, file := range files {
+		w.UpdateFreqOptions(file, opts)
+	}
+	opts.applyMinCount(w)
+	return w
+}
+
+func TestWordFreqCounterOptionsMatchesSequential(t *testing.T) {
+	files := generateCorpus(t, t.TempDir(), 9, 40)
+	want := sequentialCount(t, files, Options{})
+
+	for _, concurrency := range []int{1, 2, 4, len(files), len(files) * 2} {
+		got := make(WordCount)
+		got.WordFreqCounterOptions(files, Options{Concurrency: concurrency})
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("concurrency=%d: got %v, want %v", concurrency, got, want)
+		}
+	}
+}
+
+func TestWordFreqCounterOptionsDefaultConcurrency(t *testing.T) {
+	files := generateCorpus(t, t.TempDir(), 5, 30)
+	want := sequentialCount(t, files, Options{})
+
+	got := make(WordCount)
+	got.WordFreqCounterOptions(files, Options{})
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestWordFreqCounterOptionsEmptyFiles(t *testing.T) {
+	got := make(WordCount)
+	got.WordFreqCounterOptions(nil, Options{})
+	if len(got) != 0 {
+		t.Fatalf("got %v, want empty", got)
+	}
+}
+
+func TestWordFreqCounterOptionsMinCount(t *testing.T) {
+	files := generateCorpus(t, t.TempDir(), 9, 40)
+	opts := Options{Concurrency: 3, MinCount: 10}
+	want := sequentialCount(t, files, opts)
+	if len(want) == 0 {
+		t.Fatal("test corpus produced no words above MinCount threshold; adjust fixture")
+	}
+
+	got := make(WordCount)
+	got.WordFreqCounterOptions(files, opts)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
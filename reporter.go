@@ -0,0 +1,179 @@
+package wordcount
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+// Reporter writes a PairList to out in some format - plain text, JSON,
+// CSV - so downstream tools can consume word counts without parsing
+// the fixed-width text layout Report and SortReport produce.
+type Reporter interface {
+	Write(out io.Writer, pairs PairList) error
+}
+
+// TextReporter writes pairs in the same aligned Word/Frequency columns
+// as Report and SortReport.
+type TextReporter struct{}
+
+func (TextReporter) Write(out io.Writer, pairs PairList) error {
+	wordWidth, frequencyWidth := 0, 0
+	for _, pair := range pairs {
+		if width := utf8.RuneCountInString(pair.Key); width > wordWidth {
+			wordWidth = width
+		}
+		if width := len(fmt.Sprint(pair.Value)); width > frequencyWidth {
+			frequencyWidth = width
+		}
+	}
+	gap := wordWidth + frequencyWidth - len("Word") - len("Frequency")
+	if _, err := fmt.Fprintf(out, "Word %*s%s\n", gap, " ", "Frequency"); err != nil {
+		return err
+	}
+	for _, pair := range pairs {
+		if _, err := fmt.Fprintf(out, "%-*s %*d\n", wordWidth, pair.Key, frequencyWidth, pair.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// wordCountRecord is the JSON/NDJSON shape of one Pair.
+type wordCountRecord struct {
+	Word  string `json:"word"`
+	Count int    `json:"count"`
+}
+
+// JSONReporter writes pairs as a single JSON array:
+// [{"word":"...","count":N}, ...].
+type JSONReporter struct{}
+
+func (JSONReporter) Write(out io.Writer, pairs PairList) error {
+	records := make([]wordCountRecord, len(pairs))
+	for i, pair := range pairs {
+		records[i] = wordCountRecord{pair.Key, pair.Value}
+	}
+	return json.NewEncoder(out).Encode(records)
+}
+
+// NDJSONReporter streams pairs as newline-delimited JSON, one record
+// per line, so huge vocabularies never need to be buffered as a single
+// array before being written out.
+type NDJSONReporter struct{}
+
+func (NDJSONReporter) Write(out io.Writer, pairs PairList) error {
+	enc := json.NewEncoder(out)
+	for _, pair := range pairs {
+		if err := enc.Encode(wordCountRecord{pair.Key, pair.Value}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (NDJSONReporter) WriteHeader(io.Writer) error {
+	return nil
+}
+
+func (NDJSONReporter) WriteRow(out io.Writer, pair Pair) error {
+	return json.NewEncoder(out).Encode(wordCountRecord{pair.Key, pair.Value})
+}
+
+// RowReporter is a Reporter that can also write one row at a time,
+// without knowing the rest of the data set up front. DiskBackedCounter
+// uses it to stream a report as its external merge progresses, rather
+// than materializing the whole PairList first.
+type RowReporter interface {
+	Reporter
+	WriteHeader(out io.Writer) error
+	WriteRow(out io.Writer, pair Pair) error
+}
+
+// delimitedReporter writes pairs as delimiter-separated values with a
+// header row, via encoding/csv. It backs both CSVReporter and
+// TSVReporter.
+type delimitedReporter struct {
+	comma rune
+}
+
+func (d delimitedReporter) writeRecord(out io.Writer, record []string) error {
+	cw := csv.NewWriter(out)
+	cw.Comma = d.comma
+	if err := cw.Write(record); err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func (d delimitedReporter) Write(out io.Writer, pairs PairList) error {
+	if err := d.writeRecord(out, []string{"word", "count"}); err != nil {
+		return err
+	}
+	for _, pair := range pairs {
+		if err := d.writeRecord(out, []string{pair.Key, fmt.Sprint(pair.Value)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d delimitedReporter) WriteHeader(out io.Writer) error {
+	return d.writeRecord(out, []string{"word", "count"})
+}
+
+func (d delimitedReporter) WriteRow(out io.Writer, pair Pair) error {
+	return d.writeRecord(out, []string{pair.Key, fmt.Sprint(pair.Value)})
+}
+
+// CSVReporter writes pairs as comma-separated values with a header row.
+type CSVReporter struct{}
+
+func (CSVReporter) Write(out io.Writer, pairs PairList) error {
+	return delimitedReporter{comma: ','}.Write(out, pairs)
+}
+
+func (CSVReporter) WriteHeader(out io.Writer) error {
+	return delimitedReporter{comma: ','}.WriteHeader(out)
+}
+
+func (CSVReporter) WriteRow(out io.Writer, pair Pair) error {
+	return delimitedReporter{comma: ','}.WriteRow(out, pair)
+}
+
+// TSVReporter writes pairs as tab-separated values with a header row.
+type TSVReporter struct{}
+
+func (TSVReporter) Write(out io.Writer, pairs PairList) error {
+	return delimitedReporter{comma: '\t'}.Write(out, pairs)
+}
+
+func (TSVReporter) WriteHeader(out io.Writer) error {
+	return delimitedReporter{comma: '\t'}.WriteHeader(out)
+}
+
+func (TSVReporter) WriteRow(out io.Writer, pair Pair) error {
+	return delimitedReporter{comma: '\t'}.WriteRow(out, pair)
+}
+
+// ReporterFor resolves a -format flag value to a Reporter. An empty
+// string is treated as "text".
+func ReporterFor(format string) (Reporter, error) {
+	switch format {
+	case "", "text":
+		return TextReporter{}, nil
+	case "json":
+		return JSONReporter{}, nil
+	case "ndjson":
+		return NDJSONReporter{}, nil
+	case "csv":
+		return CSVReporter{}, nil
+	case "tsv":
+		return TSVReporter{}, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
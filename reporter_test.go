@@ -0,0 +1,118 @@
+package wordcount
+
+import (
+	"bytes"
+	"testing"
+)
+
+func testPairs() PairList {
+	return PairList{{"the", 3}, {"fox", 2}, {"dog", 1}}
+}
+
+func TestTextReporter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (TextReporter{}).Write(&buf, testPairs()); err != nil {
+		t.Fatal(err)
+	}
+	want := "Word          Frequency\n" +
+		"the 3\n" +
+		"fox 2\n" +
+		"dog 1\n"
+	if buf.String() != want {
+		t.Fatalf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestJSONReporter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (JSONReporter{}).Write(&buf, testPairs()); err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"word":"the","count":3},{"word":"fox","count":2},{"word":"dog","count":1}]` + "\n"
+	if buf.String() != want {
+		t.Fatalf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestNDJSONReporter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (NDJSONReporter{}).Write(&buf, testPairs()); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"word":"the","count":3}` + "\n" +
+		`{"word":"fox","count":2}` + "\n" +
+		`{"word":"dog","count":1}` + "\n"
+	if buf.String() != want {
+		t.Fatalf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestCSVReporter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (CSVReporter{}).Write(&buf, testPairs()); err != nil {
+		t.Fatal(err)
+	}
+	want := "word,count\nthe,3\nfox,2\ndog,1\n"
+	if buf.String() != want {
+		t.Fatalf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestTSVReporter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (TSVReporter{}).Write(&buf, testPairs()); err != nil {
+		t.Fatal(err)
+	}
+	want := "word\tcount\nthe\t3\nfox\t2\ndog\t1\n"
+	if buf.String() != want {
+		t.Fatalf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestRowReporterMatchesBatchWrite(t *testing.T) {
+	reporters := []RowReporter{NDJSONReporter{}, CSVReporter{}, TSVReporter{}}
+	for _, r := range reporters {
+		var batch bytes.Buffer
+		if err := r.Write(&batch, testPairs()); err != nil {
+			t.Fatal(err)
+		}
+
+		var streamed bytes.Buffer
+		if err := r.WriteHeader(&streamed); err != nil {
+			t.Fatal(err)
+		}
+		for _, pair := range testPairs() {
+			if err := r.WriteRow(&streamed, pair); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if batch.String() != streamed.String() {
+			t.Fatalf("%T: batch output %q != streamed output %q", r, batch.String(), streamed.String())
+		}
+	}
+}
+
+func TestReporterFor(t *testing.T) {
+	cases := map[string]Reporter{
+		"":       TextReporter{},
+		"text":   TextReporter{},
+		"json":   JSONReporter{},
+		"ndjson": NDJSONReporter{},
+		"csv":    CSVReporter{},
+		"tsv":    TSVReporter{},
+	}
+	for format, want := range cases {
+		got, err := ReporterFor(format)
+		if err != nil {
+			t.Fatalf("ReporterFor(%q): %v", format, err)
+		}
+		if got != want {
+			t.Fatalf("ReporterFor(%q) = %#v, want %#v", format, got, want)
+		}
+	}
+
+	if _, err := ReporterFor("xml"); err == nil {
+		t.Fatal("expected an error for an unknown format")
+	}
+}
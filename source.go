@@ -0,0 +1,114 @@
+package wordcount
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+)
+
+// Source produces the io.Reader for a single input to CountSources,
+// e.g. a file on disk, stdin, or a gzip-compressed file. Anything that
+// can hand back an io.Reader and a name for error messages satisfies
+// it, so callers can wrap an HTTP response body or a tar entry with
+// ReaderSource just as easily.
+type Source interface {
+	// Open returns a reader for the source's contents. If the reader
+	// also implements io.Closer, CountSources closes it once drained.
+	Open() (io.Reader, error)
+	// Name identifies the source for error messages.
+	Name() string
+}
+
+// FileSource reads a plain file from disk.
+type FileSource struct {
+	Path string
+}
+
+// NewFileSource returns a Source for the file at path.
+func NewFileSource(path string) FileSource {
+	return FileSource{Path: path}
+}
+
+func (s FileSource) Open() (io.Reader, error) {
+	return os.Open(s.Path)
+}
+
+func (s FileSource) Name() string {
+	return s.Path
+}
+
+// StdinSource reads from os.Stdin.
+type StdinSource struct{}
+
+func (StdinSource) Open() (io.Reader, error) {
+	return os.Stdin, nil
+}
+
+func (StdinSource) Name() string {
+	return "<stdin>"
+}
+
+// GzipSource reads a gzip-compressed file, decompressing as it is read.
+type GzipSource struct {
+	Path string
+}
+
+// NewGzipSource returns a Source for the gzip-compressed file at path.
+func NewGzipSource(path string) GzipSource {
+	return GzipSource{Path: path}
+}
+
+func (s GzipSource) Open() (io.Reader, error) {
+	f, err := os.Open(s.Path)
+	if err != nil {
+		return nil, err
+	}
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &gzipReadCloser{Reader: gz, file: f}, nil
+}
+
+func (s GzipSource) Name() string {
+	return s.Path
+}
+
+// gzipReadCloser closes both the gzip.Reader and the underlying file it
+// decompresses. gzip.Reader.Close does not close its underlying
+// io.Reader, so without this wrapper every GzipSource would leak the
+// *os.File it opened.
+type gzipReadCloser struct {
+	*gzip.Reader
+	file *os.File
+}
+
+func (g *gzipReadCloser) Close() error {
+	gzErr := g.Reader.Close()
+	fileErr := g.file.Close()
+	if gzErr != nil {
+		return gzErr
+	}
+	return fileErr
+}
+
+// ReaderSource adapts an already-open io.Reader, such as an HTTP
+// response body or a tar entry, into a Source.
+type ReaderSource struct {
+	Reader     io.Reader
+	SourceName string
+}
+
+// NewReaderSource wraps r, labelling it name for error messages.
+func NewReaderSource(name string, r io.Reader) ReaderSource {
+	return ReaderSource{Reader: r, SourceName: name}
+}
+
+func (s ReaderSource) Open() (io.Reader, error) {
+	return s.Reader, nil
+}
+
+func (s ReaderSource) Name() string {
+	return s.SourceName
+}
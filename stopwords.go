@@ -0,0 +1,15 @@
+package wordcount
+
+// EnglishStopwords is a built-in set of common English function words,
+// suitable for Options.Stopwords. Entries are already lower-cased, so
+// they only match when Options.CaseSensitive is false.
+var EnglishStopwords = map[string]struct{}{
+	"a": {}, "an": {}, "and": {}, "are": {}, "as": {}, "at": {}, "be": {},
+	"but": {}, "by": {}, "for": {}, "from": {}, "had": {}, "has": {}, "have": {},
+	"he": {}, "her": {}, "his": {}, "i": {}, "if": {}, "in": {}, "into": {},
+	"is": {}, "it": {}, "its": {}, "me": {}, "my": {}, "of": {}, "on": {},
+	"or": {}, "our": {}, "she": {}, "so": {}, "that": {}, "the": {}, "their": {},
+	"them": {}, "then": {}, "there": {}, "these": {}, "they": {}, "this": {},
+	"those": {}, "to": {}, "was": {}, "we": {}, "were": {}, "will": {},
+	"with": {}, "you": {}, "your": {},
+}
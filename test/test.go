@@ -1,24 +1,57 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
 	"wordcount"
 )
 
 func main() {
-	if len(os.Args) == 1 || os.Args[1] == "-h" || os.Args[1] == "--help" {
-		fmt.Printf("usage: %s <file1> [<file2> [... <fileN>]]\n",
-			filepath.Base(os.Args[0]))
+	recursive := flag.Bool("r", false, "recurse into directory arguments")
+	flag.BoolVar(recursive, "recursive", false, "recurse into directory arguments")
+	format := flag.String("format", "text", "output format: text, json, ndjson, csv, tsv")
+	outFile := flag.String("o", "", "write the report here instead of stdout")
+	flag.Usage = func() {
+		fmt.Printf("usage: %s [-r] [-format=text|json|ndjson|csv|tsv] [-o outfile] <file1> [<file2> [... <fileN>]]\n"+
+			"       %[1]s -\n"+
+			"       %[1]s @listfile\n", filepath.Base(os.Args[0]))
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
 		os.Exit(1)
 	}
 
+	reporter, err := wordcount.ReporterFor(*format)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	out := os.Stdout
+	if *outFile != "" {
+		f, err := os.Create(*outFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	files, err := wordcount.ExpandArgs(args, wordcount.ExpandOptions{Recursive: *recursive})
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	wc := make(wordcount.WordCount)
-	// for _, filename := range os.Args[1:] {
-	//  wordcount.UpdateFreq(filename)
-	// }
-	wc.WordFreqCounter(os.Args[1:])
+	wc.WordFreqCounter(files)
 
-	wc.SortReport()
+	if err := reporter.Write(out, wc.SortedPairs()); err != nil {
+		log.Fatal(err)
+	}
 }
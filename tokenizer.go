@@ -0,0 +1,172 @@
+package wordcount
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Tokenizer produces a stream of tokens from an underlying source. Next
+// returns the next token and true, or "" and false once the stream is
+// exhausted.
+type Tokenizer interface {
+	Next() (string, bool)
+}
+
+// LetterTokenizer splits its input into maximal runs of Unicode letters.
+// It is the default Tokenizer used by CountReader, and is equivalent to
+// calling SplitOnNonLetters across the whole stream rather than line by
+// line.
+type LetterTokenizer struct {
+	scanner *bufio.Scanner
+}
+
+// NewLetterTokenizer returns a Tokenizer that reads words from r.
+func NewLetterTokenizer(r io.Reader) *LetterTokenizer {
+	s := bufio.NewScanner(r)
+	s.Split(scanLetterRuns)
+	return &LetterTokenizer{scanner: s}
+}
+
+func (t *LetterTokenizer) Next() (string, bool) {
+	if t.scanner.Scan() {
+		return t.scanner.Text(), true
+	}
+	return "", false
+}
+
+// Err returns the first non-EOF error encountered while reading.
+func (t *LetterTokenizer) Err() error {
+	return t.scanner.Err()
+}
+
+// scanLetterRuns is a bufio.SplitFunc that behaves like bufio.ScanWords
+// but treats any non-letter rune as a separator, matching the semantics
+// of SplitOnNonLetters.
+func scanLetterRuns(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	start := 0
+	for start < len(data) {
+		if !atEOF && !utf8.FullRune(data[start:]) {
+			return start, nil, nil
+		}
+		r, width := utf8.DecodeRune(data[start:])
+		if unicode.IsLetter(r) {
+			break
+		}
+		start += width
+	}
+	for i := start; i < len(data); {
+		if !atEOF && !utf8.FullRune(data[i:]) {
+			return start, nil, nil
+		}
+		r, width := utf8.DecodeRune(data[i:])
+		if !unicode.IsLetter(r) {
+			return i + width, data[start:i], nil
+		}
+		i += width
+	}
+	if atEOF && len(data) > start {
+		return len(data), data[start:], nil
+	}
+	if atEOF {
+		return len(data), nil, nil
+	}
+	return start, nil, nil
+}
+
+// NGramTokenizer wraps a word Tokenizer and re-emits its tokens as
+// sliding windows of N consecutive words, e.g. with N=2 the stream
+// "the quick fox" becomes "the quick", "quick fox".
+type NGramTokenizer struct {
+	n      int
+	words  Tokenizer
+	window []string
+}
+
+// NewNGramTokenizer builds an n-gram Tokenizer over the words produced
+// by a LetterTokenizer reading r.
+func NewNGramTokenizer(r io.Reader, n int) *NGramTokenizer {
+	return NewNGramTokenizerFrom(NewLetterTokenizer(r), n)
+}
+
+// NewNGramTokenizerFrom builds an n-gram Tokenizer on top of an
+// existing word Tokenizer, so callers can combine it with RegexTokenizer
+// or any other word-level source.
+func NewNGramTokenizerFrom(words Tokenizer, n int) *NGramTokenizer {
+	return &NGramTokenizer{n: n, words: words}
+}
+
+func (t *NGramTokenizer) Next() (string, bool) {
+	for len(t.window) < t.n {
+		word, ok := t.words.Next()
+		if !ok {
+			return "", false
+		}
+		t.window = append(t.window, word)
+	}
+	gram := strings.Join(t.window, " ")
+	t.window = t.window[1:]
+	return gram, true
+}
+
+// ShingleTokenizer emits overlapping windows of N runes over the raw
+// input, the character-level analogue of NGramTokenizer, commonly used
+// for near-duplicate detection.
+type ShingleTokenizer struct {
+	runes []rune
+	n     int
+	pos   int
+}
+
+// NewShingleTokenizer reads all of r and returns a Tokenizer over its
+// N-rune shingles.
+func NewShingleTokenizer(r io.Reader, n int) (*ShingleTokenizer, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return &ShingleTokenizer{runes: []rune(string(data)), n: n}, nil
+}
+
+func (t *ShingleTokenizer) Next() (string, bool) {
+	if t.n <= 0 || t.pos+t.n > len(t.runes) {
+		return "", false
+	}
+	shingle := string(t.runes[t.pos : t.pos+t.n])
+	t.pos++
+	return shingle, true
+}
+
+// RegexTokenizer extracts tokens matching re from the input, reading
+// line by line so arbitrarily large inputs can still stream through.
+type RegexTokenizer struct {
+	scanner *bufio.Scanner
+	re      *regexp.Regexp
+	pending []string
+}
+
+// NewRegexTokenizer returns a Tokenizer that yields each match of re in
+// turn, scanning r one line at a time.
+func NewRegexTokenizer(r io.Reader, re *regexp.Regexp) *RegexTokenizer {
+	return &RegexTokenizer{scanner: bufio.NewScanner(r), re: re}
+}
+
+func (t *RegexTokenizer) Next() (string, bool) {
+	for len(t.pending) == 0 {
+		if !t.scanner.Scan() {
+			return "", false
+		}
+		t.pending = t.re.FindAllString(t.scanner.Text(), -1)
+	}
+	tok := t.pending[0]
+	t.pending = t.pending[1:]
+	return tok, true
+}
+
+// Err returns the first non-EOF error encountered while reading.
+func (t *RegexTokenizer) Err() error {
+	return t.scanner.Err()
+}
@@ -0,0 +1,90 @@
+package wordcount
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func collect(t Tokenizer) []string {
+	var tokens []string
+	for {
+		tok, ok := t.Next()
+		if !ok {
+			break
+		}
+		tokens = append(tokens, tok)
+	}
+	return tokens
+}
+
+func TestLetterTokenizer(t *testing.T) {
+	got := collect(NewLetterTokenizer(strings.NewReader("Hello, world! Bonjour; #1 café.")))
+	want := []string{"Hello", "world", "Bonjour", "café"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestNGramTokenizer(t *testing.T) {
+	got := collect(NewNGramTokenizer(strings.NewReader("the quick brown fox"), 2))
+	want := []string{"the quick", "quick brown", "brown fox"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestShingleTokenizer(t *testing.T) {
+	st, err := NewShingleTokenizer(strings.NewReader("abcd"), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := collect(st)
+	want := []string{"abc", "bcd"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestRegexTokenizer(t *testing.T) {
+	re := regexp.MustCompile(`\d+`)
+	got := collect(NewRegexTokenizer(strings.NewReader("order 12 has 3 items\nand 40 more"), re))
+	want := []string{"12", "3", "40"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestCountReaderDropsSingleRuneWords(t *testing.T) {
+	w := make(WordCount)
+	if err := w.CountReader(strings.NewReader("I am a cat. A dog, a bird.")); err != nil {
+		t.Fatal(err)
+	}
+	want := WordCount{"am": 1, "cat": 1, "dog": 1, "bird": 1}
+	if !reflect.DeepEqual(w, want) {
+		t.Fatalf("got %v, want %v", w, want)
+	}
+}
+
+func TestCountSourcesMergesAllSources(t *testing.T) {
+	sources := []Source{
+		NewReaderSource("a", strings.NewReader("apple banana apple")),
+		NewReaderSource("b", strings.NewReader("banana cherry")),
+	}
+	w, err := CountSources(sources, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := WordCount{"apple": 2, "banana": 2, "cherry": 1}
+	if !reflect.DeepEqual(w, want) {
+		t.Fatalf("got %v, want %v", w, want)
+	}
+}
+
+func TestCountSourcesOpenError(t *testing.T) {
+	_, err := CountSources([]Source{NewFileSource("/does/not/exist")}, Options{})
+	if err == nil {
+		t.Fatal("expected an error for a missing file")
+	}
+}
@@ -0,0 +1,73 @@
+package wordcount
+
+import (
+	"container/heap"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+// pairHeap is a min-heap of Pairs ordered by Value, used by TopK to
+// keep only the k largest entries seen so far.
+type pairHeap PairList
+
+func (h pairHeap) Len() int           { return len(h) }
+func (h pairHeap) Less(i, j int) bool { return h[i].Value < h[j].Value }
+func (h pairHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *pairHeap) Push(x interface{}) {
+	*h = append(*h, x.(Pair))
+}
+
+func (h *pairHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+// TopK returns the k most frequent words in w, most frequent first,
+// using a min-heap of size k so the whole map never needs sorting:
+// O(n log k) instead of SortReport's O(n log n).
+func (w WordCount) TopK(k int) PairList {
+	if k <= 0 {
+		return nil
+	}
+	h := make(pairHeap, 0, k)
+	for word, count := range w {
+		if h.Len() < k {
+			heap.Push(&h, Pair{word, count})
+			continue
+		}
+		if count > h[0].Value {
+			heap.Pop(&h)
+			heap.Push(&h, Pair{word, count})
+		}
+	}
+	result := make(PairList, h.Len())
+	for i := len(result) - 1; i >= 0; i-- {
+		result[i] = heap.Pop(&h).(Pair)
+	}
+	return result
+}
+
+// TopKReport prints the k most frequent words in w to out, padded like
+// Report.
+func (w WordCount) TopKReport(out io.Writer, k int) {
+	top := w.TopK(k)
+	wordWidth, frequencyWidth := 0, 0
+	for _, pair := range top {
+		if width := utf8.RuneCountInString(pair.Key); width > wordWidth {
+			wordWidth = width
+		}
+		if width := len(fmt.Sprint(pair.Value)); width > frequencyWidth {
+			frequencyWidth = width
+		}
+	}
+	gap := wordWidth + frequencyWidth - len("Word") - len("Frequency")
+	fmt.Fprintf(out, "Word %*s%s\n", gap, " ", "Frequency")
+	for _, pair := range top {
+		fmt.Fprintf(out, "%-*s %*d\n", wordWidth, pair.Key, frequencyWidth, pair.Value)
+	}
+}
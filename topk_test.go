@@ -0,0 +1,96 @@
+package wordcount
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTopKOrdersByFrequency(t *testing.T) {
+	w := WordCount{"the": 5, "fox": 3, "dog": 3, "a": 1}
+	got := w.TopK(2)
+	if len(got) != 2 {
+		t.Fatalf("got %d pairs, want 2: %v", len(got), got)
+	}
+	if got[0].Key != "the" || got[0].Value != 5 {
+		t.Fatalf("got[0] = %v, want the most frequent word first", got[0])
+	}
+	// fox and dog tie at 3; either may come second.
+	if got[1].Value != 3 {
+		t.Fatalf("got[1] = %v, want a count-3 word second", got[1])
+	}
+}
+
+func TestTopKZeroOrNegative(t *testing.T) {
+	w := WordCount{"a": 1}
+	if got := w.TopK(0); got != nil {
+		t.Fatalf("TopK(0) = %v, want nil", got)
+	}
+	if got := w.TopK(-1); got != nil {
+		t.Fatalf("TopK(-1) = %v, want nil", got)
+	}
+}
+
+func TestTopKLargerThanMap(t *testing.T) {
+	w := WordCount{"a": 2, "b": 1}
+	got := w.TopK(10)
+	if len(got) != 2 {
+		t.Fatalf("got %d pairs, want 2: %v", len(got), got)
+	}
+	if got[0].Key != "a" || got[1].Key != "b" {
+		t.Fatalf("got %v, want a before b", got)
+	}
+}
+
+func TestTopKEmpty(t *testing.T) {
+	empty := make(WordCount)
+	if got := empty.TopK(5); len(got) != 0 {
+		t.Fatalf("TopK on an empty WordCount = %v, want empty", got)
+	}
+}
+
+func TestOptionsMinLenMaxLen(t *testing.T) {
+	w := make(WordCount)
+	if err := w.CountReaderOptions(strings.NewReader("a ox fox foxes"), Options{MinLen: 2, MaxLen: 3}); err != nil {
+		t.Fatal(err)
+	}
+	want := WordCount{"ox": 1, "fox": 1}
+	if !reflect.DeepEqual(w, want) {
+		t.Fatalf("got %v, want %v", w, want)
+	}
+}
+
+func TestOptionsStopwords(t *testing.T) {
+	w := make(WordCount)
+	opts := Options{Stopwords: EnglishStopwords}
+	if err := w.CountReaderOptions(strings.NewReader("the fox and the dog"), opts); err != nil {
+		t.Fatal(err)
+	}
+	want := WordCount{"fox": 1, "dog": 1}
+	if !reflect.DeepEqual(w, want) {
+		t.Fatalf("got %v, want %v", w, want)
+	}
+}
+
+func TestOptionsCaseSensitive(t *testing.T) {
+	w := make(WordCount)
+	if err := w.CountReaderOptions(strings.NewReader("Fox fox FOX"), Options{CaseSensitive: true}); err != nil {
+		t.Fatal(err)
+	}
+	want := WordCount{"Fox": 1, "fox": 1, "FOX": 1}
+	if !reflect.DeepEqual(w, want) {
+		t.Fatalf("got %v, want %v", w, want)
+	}
+}
+
+func TestOptionsMinCount(t *testing.T) {
+	sources := []Source{NewReaderSource("s", strings.NewReader("apple apple banana cherry cherry cherry"))}
+	w, err := CountSources(sources, Options{MinCount: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := WordCount{"apple": 2, "cherry": 3}
+	if !reflect.DeepEqual(w, want) {
+		t.Fatalf("got %v, want %v", w, want)
+	}
+}
@@ -1,7 +1,6 @@
 package wordcount
 
 import (
-	"bufio"
 	"fmt"
 	"io"
 	"log"
@@ -53,7 +52,7 @@ func (w WordCount) Merge(wordcount WordCount) WordCount {
 }
 
 // 打印词频统计情况
-func (w WordCount) Report() {
+func (w WordCount) Report(out io.Writer) {
 	words := make([]string, 0, len(w))
 	wordWidth, frequencyWidth := 0, 0
 	for word, frequency := range w {
@@ -67,22 +66,33 @@ func (w WordCount) Report() {
 	}
 	sort.Strings(words)
 	gap := wordWidth + frequencyWidth - len("Word") - len("Frequency")
-	fmt.Printf("Word %*s%s\n", gap, " ", "Frequency")
+	fmt.Fprintf(out, "Word %*s%s\n", gap, " ", "Frequency")
 	for _, word := range words {
-		fmt.Printf("%-*s %*d\n", wordWidth, word, frequencyWidth, w[word])
+		fmt.Fprintf(out, "%-*s %*d\n", wordWidth, word, frequencyWidth, w[word])
 	}
 }
 
-// 从多到少打印词频
-func (w WordCount) SortReport() {
-	p := make(PairList, len(w))
-	i := 0
+// ReportStdout prints Report to os.Stdout, preserving the package's
+// original zero-argument calling convention.
+func (w WordCount) ReportStdout() {
+	w.Report(os.Stdout)
+}
+
+// SortedPairs converts w to a PairList ordered from most to least
+// frequent, for callers that want the counts themselves rather than a
+// printed report (e.g. Reporter implementations).
+func (w WordCount) SortedPairs() PairList {
+	p := make(PairList, 0, len(w))
 	for k, v := range w {
-		// 将 w map 转换成 PairList
-		p[i] = Pair{k, v}
-		i++
+		p = append(p, Pair{k, v})
 	}
 	sort.Sort(p) // 因为PairList实现了排序接口，所以可以使用sort.Sort()对其排序
+	return p
+}
+
+// 从多到少打印词频
+func (w WordCount) SortReport(out io.Writer) {
+	p := w.SortedPairs()
 	wordWidth, frequencyWidth := 0, 0
 	for _, pair := range p {
 		word, frequency := pair.Key, pair.Value
@@ -94,83 +104,158 @@ func (w WordCount) SortReport() {
 		}
 	}
 	gap := wordWidth + frequencyWidth - len("Word") - len("Frequency")
-	fmt.Printf("Word %*s%s\n", gap, " ", "Frequency")
+	fmt.Fprintf(out, "Word %*s%s\n", gap, " ", "Frequency")
 	for _, pair := range p {
-		fmt.Printf("%-*s %*d\n", wordWidth, pair.Key, frequencyWidth, pair.Value)
+		fmt.Fprintf(out, "%-*s %*d\n", wordWidth, pair.Key, frequencyWidth, pair.Value)
+	}
+}
+
+// SortReportStdout prints SortReport to os.Stdout, preserving the
+// package's original zero-argument calling convention.
+func (w WordCount) SortReportStdout() {
+	w.SortReport(os.Stdout)
+}
+
+// InvertByFrequency turns w inside out: each frequency maps to the
+// words that occur that many times, so callers can answer "which words
+// all occur N times" without scanning the whole map.
+func (w WordCount) InvertByFrequency() map[int][]string {
+	inv := make(map[int][]string)
+	for word, frequency := range w {
+		inv[frequency] = append(inv[frequency], word)
+	}
+	for _, words := range inv {
+		sort.Strings(words)
+	}
+	return inv
+}
+
+// ReportByFrequency prints frequencies in descending order, with the
+// words that share each frequency listed alphabetically underneath,
+// padded like Report.
+func (w WordCount) ReportByFrequency(out io.Writer) {
+	inv := w.InvertByFrequency()
+	frequencies := make([]int, 0, len(inv))
+	frequencyWidth := 0
+	for frequency := range inv {
+		frequencies = append(frequencies, frequency)
+		if width := len(fmt.Sprint(frequency)); width > frequencyWidth {
+			frequencyWidth = width
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(frequencies)))
+
+	for _, frequency := range frequencies {
+		words := inv[frequency]
+		fmt.Fprintf(out, "%*d %s\n", frequencyWidth, frequency, words[0])
+		for _, word := range words[1:] {
+			fmt.Fprintf(out, "%*s %s\n", frequencyWidth, "", word)
+		}
+	}
+}
+
+// defaultMinLen preserves the package's original behaviour of dropping
+// single-rune words (the old `len(word) > utf8.UTFMax ||
+// RuneCount > 1` check). The bare, no-Options entry points below -
+// CountReader, UpdateFreq, WordFreqCounter - pass it explicitly so
+// existing callers keep seeing the same output; callers who build
+// their own Options (via the *Options variants, or CountSources) get
+// MinLen's real zero-value ("no minimum") unless they set it.
+const defaultMinLen = 2
+
+// CountReader tokenizes r with the default LetterTokenizer and adds the
+// words it finds to w. It lets callers feed the counter from anything
+// that produces an io.Reader - stdin, an HTTP body, a gzip stream -
+// without going through a file on disk.
+func (w WordCount) CountReader(r io.Reader) error {
+	return w.CountReaderOptions(r, Options{MinLen: defaultMinLen})
+}
+
+// CountReaderOptions is CountReader with filtering and case-folding
+// controlled by opts; see Options.
+func (w WordCount) CountReaderOptions(r io.Reader, opts Options) error {
+	return w.countTokens(opts.newTokenizer(r), opts)
+}
+
+// countTokens drains t into w, folding and filtering each token per
+// opts.
+func (w WordCount) countTokens(t Tokenizer, opts Options) error {
+	for {
+		word, ok := t.Next()
+		if !ok {
+			break
+		}
+		word = opts.fold(word)
+		if opts.keep(word) {
+			w[word] += 1
+		}
+	}
+	if e, ok := t.(interface{ Err() error }); ok {
+		return e.Err()
 	}
+	return nil
 }
 
 // 从文件中读取单词，并更新其出现的次数
 func (w WordCount) UpdateFreq(fileName string) {
-	var file *os.File
-	var err error
-	if file, err = os.Open(fileName); err != nil {
+	w.UpdateFreqOptions(fileName, Options{MinLen: defaultMinLen})
+}
+
+// UpdateFreqOptions is UpdateFreq with filtering and case-folding
+// controlled by opts; see Options.
+func (w WordCount) UpdateFreqOptions(fileName string, opts Options) {
+	file, err := os.Open(fileName)
+	if err != nil {
 		log.Println("failed to open the file: ", err)
 		return
 	}
 	defer file.Close() // 本函数退出之前时，关闭文件
 
-	reader := bufio.NewReader(file)
-	for {
-		line, err := reader.ReadString('\n')
-		for _, word := range SplitOnNonLetters(strings.TrimSpace(line)) {
-			if len(word) > utf8.UTFMax || utf8.RuneCountInString(word) > 1 {
-				w[strings.ToLower(word)] += 1
-			}
-		}
-		if err != nil {
-			if err != io.EOF {
-				log.Println("failed to finish reading the file: ", err)
-			}
-			break
-		}
+	if err := w.CountReaderOptions(file, opts); err != nil {
+		log.Println("failed to finish reading the file: ", err)
 	}
 }
 
-// 并发统计单词频次
-func (w WordCount) WordFreqCounter(files []string) {
-	// goroutine 将结果发送到该channel
-	results := make(chan Pair, len(files))
-	// 每个goroutine工作完成后，发送一个空结构体到该channel，表示工作完成
-	done := make(chan struct{}, len(files))
-
-	for i := 0; i < len(files); {
-		// 有多少个文件就开启多少个goroutine, 使用匿名函数的方式
-		go func(done chan<- struct{}, results chan<- Pair, filename string) {
-			w := make(WordCount)
-			w.UpdateFreq(filename)
-			for k, v := range w {
-				pair := Pair{k, v}
-				results <- pair
-			}
-			done <- struct{}{}
-		}(done, results, files[i])
-
-		i++
-	}
-
-	for working := len(files); working > 0; {
-		// 监听通道，直到所有的工作goroutine完成任务时才退出
-		select {
-		case pair := <-results:
-			// 接收发送到通道中的统计结果
-			w[pair.Key] += pair.Value
-		case <-done:
-			// 判断工作goroutine是否全部完成
-			working--
-
+// CountSources reads every Source in turn, tokenizing each with
+// opts.Tokenizer (or the default LetterTokenizer), and returns the
+// merged word counts after applying opts.MinCount. Sources are read
+// sequentially; see Options.Concurrency on WordFreqCounterOptions for
+// parallel counting of files on disk.
+func CountSources(sources []Source, opts Options) (WordCount, error) {
+	w := make(WordCount)
+	for _, src := range sources {
+		if err := countSource(w, src, opts); err != nil {
+			return nil, err
 		}
 	}
+	opts.applyMinCount(w)
+	return w, nil
+}
 
-DONE: // 再次启动for循环处理通道中还未处理完的值
-	for {
-		select {
-		case pair := <-results:
-			w[pair.Key] += pair.Value
-		default:
-			break DONE
+// countSource opens a single Source, drains it into w, and closes it
+// again before returning, so CountSources never holds more than one
+// source's file descriptor open at a time regardless of how many
+// sources it is given.
+func countSource(w WordCount, src Source, opts Options) error {
+	r, err := src.Open()
+	if err != nil {
+		return fmt.Errorf("open %s: %w", src.Name(), err)
+	}
+	readErr := w.countTokens(opts.newTokenizer(r), opts)
+	if closer, ok := r.(io.Closer); ok {
+		if closeErr := closer.Close(); readErr == nil {
+			readErr = closeErr
 		}
 	}
-	close(results)
-	close(done)
+	if readErr != nil {
+		return fmt.Errorf("read %s: %w", src.Name(), readErr)
+	}
+	return nil
+}
+
+// WordFreqCounter counts files concurrently with the default
+// Options, i.e. a worker pool sized by runtime.GOMAXPROCS(0). See
+// WordFreqCounterOptions to control concurrency.
+func (w WordCount) WordFreqCounter(files []string) {
+	w.WordFreqCounterOptions(files, Options{MinLen: defaultMinLen})
 }